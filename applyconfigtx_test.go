@@ -0,0 +1,168 @@
+package gofrr
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+func newTestConnection(conn net.Conn) *Connection {
+	return &Connection{socketPath: "test", conn: conn, logger: noopLogger{}, backoff: DefaultBackoff()}
+}
+
+func TestApplyConfigTxSuccess(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+
+	var executed []string
+	serveFakeFRR(t, serverConn, func(cmd string) ([]byte, StatusCode) {
+		executed = append(executed, cmd)
+		return nil, Success
+	})
+
+	c := newTestConnection(clientConn)
+
+	config := []byte("ip route 10.0.0.0/24 eth0\nno ip route 10.0.0.0/8 eth0\n")
+	result, err := c.ApplyConfigTx(context.Background(), config, ApplyConfigTxOptions{})
+	if err != nil {
+		t.Fatalf("ApplyConfigTx: %v", err)
+	}
+	if result.RolledBack {
+		t.Fatal("RolledBack = true, want false on success")
+	}
+
+	wantExecuted := []string{"enable", "configure", "ip route 10.0.0.0/24 eth0", "no ip route 10.0.0.0/8 eth0", "exit", "disable"}
+	if len(executed) != len(wantExecuted) {
+		t.Fatalf("executed = %v, want %v", executed, wantExecuted)
+	}
+	for i, cmd := range wantExecuted {
+		if executed[i] != cmd {
+			t.Errorf("executed[%d] = %q, want %q", i, executed[i], cmd)
+		}
+	}
+
+	if len(result.Lines) != 2 || result.Lines[0].StatusCode != Success || result.Lines[1].StatusCode != Success {
+		t.Fatalf("result.Lines = %+v, want both config lines reported as Success", result.Lines)
+	}
+}
+
+func TestApplyConfigTxStopOnFirstError(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+
+	var executed []string
+	serveFakeFRR(t, serverConn, func(cmd string) ([]byte, StatusCode) {
+		executed = append(executed, cmd)
+		if cmd == "bad command" {
+			return []byte("% Unknown command"), ErrNoMatch
+		}
+		return nil, Success
+	})
+
+	c := newTestConnection(clientConn)
+
+	config := []byte("good one\nbad command\nshould not run\n")
+	result, err := c.ApplyConfigTx(context.Background(), config, ApplyConfigTxOptions{StopOnFirstError: true})
+	if err == nil {
+		t.Fatal("expected an error from the rejected command")
+	}
+	if !errors.Is(err, ErrNotAcceptableStatusCode) {
+		t.Fatalf("err = %v, want it to wrap ErrNotAcceptableStatusCode", err)
+	}
+
+	if len(result.Lines) != 2 {
+		t.Fatalf("len(result.Lines) = %d, want 2 (stopped right after the failing line)", len(result.Lines))
+	}
+	if result.Lines[1].StatusCode != ErrNoMatch {
+		t.Fatalf("result.Lines[1].StatusCode = %s, want ErrNoMatch", result.Lines[1].StatusCode)
+	}
+
+	for _, cmd := range executed {
+		if cmd == "should not run" {
+			t.Fatal("a command after the failing one ran despite StopOnFirstError")
+		}
+	}
+}
+
+func TestApplyConfigTxRollbackOnError(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+
+	const snapshot = "Building configuration...\n\nCurrent configuration:\n!\noriginal line one\noriginal line two\n!\nend\n"
+
+	var executed []string
+	serveFakeFRR(t, serverConn, func(cmd string) ([]byte, StatusCode) {
+		executed = append(executed, cmd)
+		switch cmd {
+		case "do write terminal":
+			return []byte(snapshot), Success
+		case "bad command":
+			return nil, ErrNoMatch
+		default:
+			return nil, Success
+		}
+	})
+
+	c := newTestConnection(clientConn)
+
+	config := []byte("good one\nbad command\n")
+	result, err := c.ApplyConfigTx(context.Background(), config, ApplyConfigTxOptions{RollbackOnError: true})
+	if err == nil {
+		t.Fatal("expected an error from the rejected command")
+	}
+	if !result.RolledBack {
+		t.Fatal("result.RolledBack = false, want true after a successful rollback replay")
+	}
+
+	for _, cmd := range executed {
+		if cmd == "Building configuration..." || cmd == "Current configuration:" || cmd == "end" {
+			t.Fatalf("banner/trailer line %q from the snapshot was replayed as a command", cmd)
+		}
+	}
+
+	var replayed int
+	for _, cmd := range executed {
+		if cmd == "original line one" || cmd == "original line two" {
+			replayed++
+		}
+	}
+	if replayed != 2 {
+		t.Fatalf("both snapshot config lines should have been replayed during rollback, got %d/2", replayed)
+	}
+}
+
+func TestApplyConfigTxPreCheckAbortsBeforeMutation(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+
+	executed := 0
+	serveFakeFRR(t, serverConn, func(cmd string) ([]byte, StatusCode) {
+		executed++
+		return nil, Success
+	})
+
+	c := newTestConnection(clientConn)
+
+	var preCheckCalls []string
+	opts := ApplyConfigTxOptions{
+		PreCheck: func(_ context.Context, cmd string) (StatusCode, error) {
+			preCheckCalls = append(preCheckCalls, cmd)
+			if cmd == "bad command" {
+				return ErrAmbiguous, nil
+			}
+			return CompleteFullMatch, nil
+		},
+	}
+
+	_, err := c.ApplyConfigTx(context.Background(), []byte("good one\nbad command\n"), opts)
+	if err == nil {
+		t.Fatal("expected an error from the rejected pre-check")
+	}
+	if executed != 0 {
+		t.Fatalf("executed = %d commands, want 0: PreCheck should abort before any mutation runs", executed)
+	}
+	if len(preCheckCalls) != 2 {
+		t.Fatalf("preCheckCalls = %v, want both lines pre-checked", preCheckCalls)
+	}
+}
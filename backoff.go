@@ -0,0 +1,61 @@
+package gofrr
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Backoff computes retry delays using exponential backoff with jitter, modeled on the backoff
+// strategy gRPC uses by default (see BackoffConfig in google.golang.org/grpc/backoff). Users
+// needing a different strategy can construct their own Backoff and plug it in via WithBackoff.
+type Backoff struct {
+	// BaseDelay is the delay before the first retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay, irrespective of Multiplier. The cap is applied before Jitter, so a
+	// returned delay can exceed MaxDelay by up to Jitter*MaxDelay.
+	MaxDelay time.Duration
+
+	// Multiplier is applied to the delay after each unsuccessful attempt.
+	Multiplier float64
+
+	// Jitter is the fraction of randomness applied to each delay, e.g. 0.2 means +/-20%.
+	Jitter float64
+
+	// MaxAttempts caps the number of attempts. Zero means unlimited, bounded only by the context
+	// passed to Connect/ExecuteIdempotent.
+	MaxAttempts int
+}
+
+// DefaultBackoff returns the backoff policy gRPC uses by default: 1s base delay, 1.6x multiplier,
+// 0.2 jitter and a 120s cap, with no limit on the number of attempts.
+func DefaultBackoff() Backoff {
+	return Backoff{
+		BaseDelay:  1 * time.Second,
+		MaxDelay:   120 * time.Second,
+		Multiplier: 1.6,
+		Jitter:     0.2,
+	}
+}
+
+// Delay returns the delay to wait before retry attempt n (0-indexed: n=0 is the delay after the
+// first, initial attempt failed).
+func (b Backoff) Delay(n int) time.Duration {
+	backoff, maxDelay := float64(b.BaseDelay), float64(b.MaxDelay)
+	for i := 0; i < n && backoff < maxDelay; i++ {
+		backoff *= b.Multiplier
+	}
+	if backoff > maxDelay {
+		backoff = maxDelay
+	}
+
+	delta := b.Jitter * backoff
+	jittered := backoff - delta + 2*delta*rand.Float64()
+	return time.Duration(jittered)
+}
+
+// WithBackoff sets the backoff policy used by Connect and ExecuteIdempotent to retry on
+// transient failures. The default is DefaultBackoff().
+func WithBackoff(b Backoff) Option {
+	return func(o *options) { o.backoff = b }
+}
@@ -0,0 +1,60 @@
+package gofrr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffDelayNoJitter(t *testing.T) {
+	t.Parallel()
+
+	b := Backoff{
+		BaseDelay:  100 * time.Millisecond,
+		MaxDelay:   1 * time.Second,
+		Multiplier: 2,
+		Jitter:     0,
+	}
+
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 100 * time.Millisecond},
+		{1, 200 * time.Millisecond},
+		{2, 400 * time.Millisecond},
+		{3, 800 * time.Millisecond},
+		{4, 1 * time.Second},  // growth would exceed MaxDelay, so capped
+		{10, 1 * time.Second}, // still capped
+	}
+
+	for _, tc := range cases {
+		if got := b.Delay(tc.attempt); got != tc.want {
+			t.Errorf("Delay(%d) = %v, want %v", tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestBackoffDelayJitterBounds(t *testing.T) {
+	t.Parallel()
+
+	b := Backoff{
+		BaseDelay:  100 * time.Millisecond,
+		MaxDelay:   1 * time.Second,
+		Multiplier: 2,
+		Jitter:     0.2,
+	}
+
+	maxJittered := b.MaxDelay + time.Duration(float64(b.MaxDelay)*b.Jitter)
+
+	for i := 0; i < 1000; i++ {
+		for attempt := 0; attempt < 6; attempt++ {
+			got := b.Delay(attempt)
+			if got < 0 {
+				t.Fatalf("Delay(%d) = %v, want non-negative", attempt, got)
+			}
+			if got > maxJittered {
+				t.Fatalf("Delay(%d) = %v, exceeds jittered max delay %v", attempt, got, maxJittered)
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package gofrr
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"testing"
+)
+
+// respondFunc returns the plaintext response and status code a fake FRR daemon should send back
+// for cmd.
+type respondFunc func(cmd string) ([]byte, StatusCode)
+
+// serveFakeFRR runs a single-connection fake FRR daemon on conn until conn is closed or a read/write
+// fails, answering every command read off the wire via respond. It mirrors the wire protocol
+// documented in spec.go: commands are NUL-terminated, responses are plaintext followed by
+// terminationMarker and one status byte.
+func serveFakeFRR(t *testing.T, conn net.Conn, respond respondFunc) {
+	t.Helper()
+
+	go func() {
+		r := bufio.NewReader(conn)
+		for {
+			cmdBytes, err := r.ReadBytes(0)
+			if err != nil {
+				return
+			}
+			cmd := string(bytes.TrimSuffix(cmdBytes, []byte{0}))
+
+			resp, sc := respond(cmd)
+			out := append(append([]byte(nil), resp...), terminationMarker...)
+			out = append(out, byte(sc))
+			if _, err := conn.Write(out); err != nil {
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,39 @@
+package gofrr
+
+// Logger is a small structured logging interface used to instrument Connection and Sockets.
+// It is intentionally narrow so that logrus, zap's SugaredLogger, slog-based wrappers and similar
+// loggers can satisfy it with little or no adapting.
+type Logger interface {
+	Debugf(format string, args ...any)
+	Infof(format string, args ...any)
+	Warnf(format string, args ...any)
+	Errorf(format string, args ...any)
+}
+
+// noopLogger is the default Logger, used when none is supplied via WithLogger, so existing users
+// see no change in behavior.
+type noopLogger struct{}
+
+func (noopLogger) Debugf(string, ...any) {}
+func (noopLogger) Infof(string, ...any)  {}
+func (noopLogger) Warnf(string, ...any)  {}
+func (noopLogger) Errorf(string, ...any) {}
+
+// options holds configuration shared by NewConnection and NewSockets, populated via Option functions.
+type options struct {
+	logger  Logger
+	backoff Backoff
+}
+
+func defaultOptions() options {
+	return options{logger: noopLogger{}, backoff: DefaultBackoff()}
+}
+
+// Option configures a Connection or Sockets instance.
+type Option func(*options)
+
+// WithLogger sets the Logger used to instrument command execution on a Connection or Sockets.
+// The default is a no-op logger, so existing callers see no change in behavior.
+func WithLogger(l Logger) Option {
+	return func(o *options) { o.logger = l }
+}
@@ -0,0 +1,94 @@
+package gofrr
+
+import (
+	"context"
+	"net"
+	"testing"
+)
+
+// recordingLogger counts calls per level so tests can assert which ones fired without caring
+// about exact message text.
+type recordingLogger struct {
+	debugf, infof, warnf, errorf int
+}
+
+func (l *recordingLogger) Debugf(string, ...any) { l.debugf++ }
+func (l *recordingLogger) Infof(string, ...any)  { l.infof++ }
+func (l *recordingLogger) Warnf(string, ...any)  { l.warnf++ }
+func (l *recordingLogger) Errorf(string, ...any) { l.errorf++ }
+
+func TestNewConnectionDefaultsToNoopLogger(t *testing.T) {
+	c := NewConnection("unused")
+	if _, ok := c.logger.(noopLogger); !ok {
+		t.Fatalf("default logger = %T, want noopLogger", c.logger)
+	}
+}
+
+func TestWithLoggerAppliedToConnection(t *testing.T) {
+	rl := &recordingLogger{}
+	c := NewConnection("unused", WithLogger(rl))
+	if c.logger != Logger(rl) {
+		t.Fatal("WithLogger did not wire the logger into the Connection")
+	}
+}
+
+func TestWithLoggerAppliedToSockets(t *testing.T) {
+	rl := &recordingLogger{}
+	s := NewSockets("/tmp/frr.conf", "/tmp", WithLogger(rl))
+
+	if s.logger != Logger(rl) {
+		t.Error("WithLogger did not wire the logger into Sockets itself")
+	}
+	for name, conn := range map[string]*Connection{
+		"bgp conn":   s.bgpConn,
+		"bfd conn":   s.bfdConn,
+		"mgmt conn":  s.mgmtConn,
+		"zebra conn": s.zebraConn,
+	} {
+		if conn.logger != Logger(rl) {
+			t.Errorf("%s logger not propagated from NewSockets' WithLogger", name)
+		}
+	}
+}
+
+func TestExecuteLogsAcceptedAndRejectedCommands(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+
+	serveFakeFRR(t, serverConn, func(cmd string) ([]byte, StatusCode) {
+		if cmd == "bad" {
+			return nil, ErrNoMatch
+		}
+		return []byte("ok"), Success
+	})
+
+	rl := &recordingLogger{}
+	c := newTestConnection(clientConn)
+	c.logger = rl
+
+	if _, err := c.Execute(context.Background(), "good"); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if rl.debugf != 1 {
+		t.Fatalf("debugf calls = %d, want 1 after an accepted command", rl.debugf)
+	}
+
+	if _, err := c.Execute(context.Background(), "bad"); err == nil {
+		t.Fatal("expected an error for a rejected status code")
+	}
+	if rl.warnf != 1 {
+		t.Fatalf("warnf calls = %d, want 1 after a rejected command", rl.warnf)
+	}
+}
+
+func TestExecuteLogsErrorWhenNotConnected(t *testing.T) {
+	rl := &recordingLogger{}
+	c := NewConnection("unused", WithLogger(rl))
+
+	if _, err := c.Execute(context.Background(), "show version"); err == nil {
+		t.Fatal("expected an error executing on an unconnected socket")
+	}
+	if rl.errorf != 1 {
+		t.Fatalf("errorf calls = %d, want 1 for an unconnected socket", rl.errorf)
+	}
+}
@@ -0,0 +1,136 @@
+package gofrr
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+	"time"
+)
+
+func fastBackoff(maxAttempts int) Backoff {
+	return Backoff{
+		BaseDelay:   5 * time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+		Multiplier:  1,
+		Jitter:      0,
+		MaxAttempts: maxAttempts,
+	}
+}
+
+func TestConnectRetriesUntilSocketAppears(t *testing.T) {
+	sockPath := t.TempDir() + "/test.sock"
+
+	c := NewConnection(sockPath, WithBackoff(fastBackoff(40)))
+
+	// The socket doesn't exist yet, forcing Connect to retry a few times before it does.
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		ln, err := net.Listen("unix", sockPath)
+		if err != nil {
+			return
+		}
+		defer ln.Close()
+		if conn, err := ln.Accept(); err == nil {
+			conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer c.Close()
+}
+
+func TestConnectGivesUpAfterMaxAttempts(t *testing.T) {
+	sockPath := t.TempDir() + "/does-not-exist.sock"
+
+	c := NewConnection(sockPath, WithBackoff(fastBackoff(3)))
+
+	if err := c.Connect(context.Background()); err == nil {
+		t.Fatal("expected Connect to fail: the socket never comes up")
+	}
+}
+
+func TestConnectStopsWhenContextIsDone(t *testing.T) {
+	sockPath := t.TempDir() + "/does-not-exist.sock"
+
+	c := NewConnection(sockPath, WithBackoff(fastBackoff(0)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := c.Connect(ctx); err == nil {
+		t.Fatal("expected Connect to fail once the context is done")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Connect took %v to give up after its context expired, want well under 1s", elapsed)
+	}
+}
+
+// TestExecuteIdempotentSurvivesTransientDisconnectAndRedialFailure reproduces the scenario a live
+// daemon restart creates: the connection drops mid-session, the socket is briefly gone while the
+// daemon restarts (so the first redial attempt fails), and then it reappears and the retried
+// command succeeds. ExecuteIdempotent must keep retrying the redial itself rather than giving up
+// as soon as one reconnect attempt fails.
+func TestExecuteIdempotentSurvivesTransientDisconnectAndRedialFailure(t *testing.T) {
+	sockPath := t.TempDir() + "/test.sock"
+
+	ln, err := net.Listen("unix", sockPath)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		// Drop the connection without responding, simulating the daemon dying mid-command.
+		conn.Close()
+		ln.Close()
+		_ = os.Remove(sockPath)
+
+		// The socket is gone for a bit, simulating the daemon still restarting: the next redial
+		// attempt(s) must fail and be retried rather than aborting the whole call.
+		time.Sleep(30 * time.Millisecond)
+
+		ln2, err := net.Listen("unix", sockPath)
+		if err != nil {
+			return
+		}
+		defer ln2.Close()
+
+		conn2, err := ln2.Accept()
+		if err != nil {
+			return
+		}
+		defer conn2.Close()
+
+		serveFakeFRR(t, conn2, func(cmd string) ([]byte, StatusCode) {
+			return []byte("ok"), Success
+		})
+		time.Sleep(200 * time.Millisecond)
+	}()
+
+	c := NewConnection(sockPath, WithBackoff(fastBackoff(40)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("initial connect: %v", err)
+	}
+
+	resp, err := c.ExecuteIdempotent(ctx, "show version")
+	if err != nil {
+		t.Fatalf("ExecuteIdempotent: %v", err)
+	}
+	if string(resp) != "ok" {
+		t.Fatalf("resp = %q, want %q", resp, "ok")
+	}
+}
@@ -0,0 +1,46 @@
+package show
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/RealFatCat/gofrr"
+)
+
+// BFDPeer is one entry of BFDPeersResponse.
+type BFDPeer struct {
+	Peer                 string      `json:"peer"`
+	Local                string      `json:"local"`
+	VRF                  string      `json:"vrf"`
+	Interface            string      `json:"interface"`
+	ID                   int         `json:"id"`
+	RemoteID             int         `json:"remote-id"`
+	Status               string      `json:"status"`
+	Uptime               SecDuration `json:"uptime"`
+	Diagnostic           string      `json:"diagnostic"`
+	RemoteDiagnostic     string      `json:"remote-diagnostic"`
+	ReceiveInterval      int         `json:"receive-interval"`
+	TransmitInterval     int         `json:"transmit-interval"`
+	EchoReceiveInterval  int         `json:"echo-receive-interval"`
+	EchoTransmitInterval int         `json:"echo-transmit-interval"`
+	DetectMultiplier     int         `json:"detect-multiplier"`
+	Type                 string      `json:"type"`
+}
+
+// BFDPeersResponse mirrors the JSON array produced by 'show bfd peers json'.
+type BFDPeersResponse []BFDPeer
+
+// BFDPeers runs 'show bfd peers json' on the BFD socket and unmarshals the result.
+func BFDPeers(ctx context.Context, s *gofrr.Sockets) (*BFDPeersResponse, error) {
+	resp, err := s.ExecuteBFD(ctx, "show bfd peers json")
+	if err != nil {
+		return nil, fmt.Errorf("show bfd peers json: %w", err)
+	}
+
+	var out BFDPeersResponse
+	if err := json.Unmarshal(resp, &out); err != nil {
+		return nil, fmt.Errorf("unmarshal show bfd peers json response: %w", err)
+	}
+	return &out, nil
+}
@@ -0,0 +1,92 @@
+package show
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/RealFatCat/gofrr"
+)
+
+// BGPPeerSummary is one peer entry within BGPAFISummary.Peers.
+type BGPPeerSummary struct {
+	RemoteAS            int          `json:"remoteAs"`
+	Version             int          `json:"version"`
+	MsgRcvd             int          `json:"msgRcvd"`
+	MsgSent             int          `json:"msgSent"`
+	TableVersion        int          `json:"tableVersion"`
+	Outq                int          `json:"outq"`
+	Inq                 int          `json:"inq"`
+	PeerUptime          MsecDuration `json:"peerUptimeMsec"`
+	PrefixReceivedCount int          `json:"prefixReceivedCount"`
+	State               string       `json:"state"`
+	IDType              string       `json:"idType"`
+}
+
+// BGPAFISummary is the per-AFI/SAFI summary reported by 'show bgp summary json', held as a value
+// in BGPSummaryResponse keyed by AFI/SAFI name (e.g. "ipv4Unicast", "ipv6Unicast").
+type BGPAFISummary struct {
+	RouterID       string                    `json:"routerId"`
+	AS             int                       `json:"as"`
+	VRFID          int                       `json:"vrfId"`
+	VRFName        string                    `json:"vrfName"`
+	TableVersion   int                       `json:"tableVersion"`
+	RIBCount       int                       `json:"ribCount"`
+	RIBMemory      int                       `json:"ribMemory"`
+	PeerCount      int                       `json:"peerCount"`
+	PeerMemory     int                       `json:"peerMemory"`
+	Peers          map[string]BGPPeerSummary `json:"peers"`
+	FailedPeers    int                       `json:"failedPeers"`
+	DisplayedPeers int                       `json:"displayedPeers"`
+	TotalPeers     int                       `json:"totalPeers"`
+	DynamicPeers   int                       `json:"dynamicPeers"`
+}
+
+// BGPSummaryResponse mirrors the JSON produced by 'show bgp summary json', keyed by AFI/SAFI name
+// (e.g. "ipv4Unicast", "ipv6Unicast").
+type BGPSummaryResponse map[string]BGPAFISummary
+
+// BGPSummary runs 'show bgp summary json' on the BGP socket and unmarshals the result.
+func BGPSummary(ctx context.Context, s *gofrr.Sockets) (*BGPSummaryResponse, error) {
+	resp, err := s.ExecuteBGP(ctx, "show bgp summary json")
+	if err != nil {
+		return nil, fmt.Errorf("show bgp summary json: %w", err)
+	}
+
+	var out BGPSummaryResponse
+	if err := json.Unmarshal(resp, &out); err != nil {
+		return nil, fmt.Errorf("unmarshal show bgp summary json response: %w", err)
+	}
+	return &out, nil
+}
+
+// BGPNeighbor is one neighbor entry within BGPNeighborsResponse.
+type BGPNeighbor struct {
+	RemoteAS       int          `json:"remoteAs"`
+	LocalAS        int          `json:"localAs"`
+	RemoteRouterID string       `json:"remoteRouterId"`
+	BGPState       string       `json:"bgpState"`
+	BGPTimerUp     MsecDuration `json:"bgpTimerUpMsec"`
+	HostLocal      string       `json:"hostLocal"`
+	PortLocal      int          `json:"portLocal"`
+	HostForeign    string       `json:"hostForeign"`
+	PortForeign    int          `json:"portForeign"`
+}
+
+// BGPNeighborsResponse mirrors the JSON produced by 'show bgp neighbors json', keyed by neighbor
+// address.
+type BGPNeighborsResponse map[string]BGPNeighbor
+
+// BGPNeighbors runs 'show bgp neighbors json' on the BGP socket and unmarshals the result.
+func BGPNeighbors(ctx context.Context, s *gofrr.Sockets) (*BGPNeighborsResponse, error) {
+	resp, err := s.ExecuteBGP(ctx, "show bgp neighbors json")
+	if err != nil {
+		return nil, fmt.Errorf("show bgp neighbors json: %w", err)
+	}
+
+	var out BGPNeighborsResponse
+	if err := json.Unmarshal(resp, &out); err != nil {
+		return nil, fmt.Errorf("unmarshal show bgp neighbors json response: %w", err)
+	}
+	return &out, nil
+}
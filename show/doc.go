@@ -0,0 +1,4 @@
+// Package show provides typed request/response helpers for FRR's 'show ... json' commands, built
+// on top of gofrr's Execute* methods. Callers who want plaintext vtysh output should keep using
+// those methods directly; this package only adds a typed layer on top of them.
+package show
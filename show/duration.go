@@ -0,0 +1,40 @@
+package show
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// MsecDuration unmarshals a JSON number of milliseconds, as FRR emits for fields like BGP's
+// 'peerUptimeMsec', directly into a time.Duration.
+type MsecDuration time.Duration
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *MsecDuration) UnmarshalJSON(b []byte) error {
+	var msec int64
+	if err := json.Unmarshal(b, &msec); err != nil {
+		return err
+	}
+	*d = MsecDuration(time.Duration(msec) * time.Millisecond)
+	return nil
+}
+
+// Duration returns d as a time.Duration.
+func (d MsecDuration) Duration() time.Duration { return time.Duration(d) }
+
+// SecDuration unmarshals a JSON number of seconds, as FRR emits for fields like BFD's 'uptime',
+// directly into a time.Duration.
+type SecDuration time.Duration
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *SecDuration) UnmarshalJSON(b []byte) error {
+	var sec int64
+	if err := json.Unmarshal(b, &sec); err != nil {
+		return err
+	}
+	*d = SecDuration(time.Duration(sec) * time.Second)
+	return nil
+}
+
+// Duration returns d as a time.Duration.
+func (d SecDuration) Duration() time.Duration { return time.Duration(d) }
@@ -0,0 +1,55 @@
+package show
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestMsecDurationUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var d MsecDuration
+	if err := json.Unmarshal([]byte(`600000`), &d); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if want := 600 * time.Second; d.Duration() != want {
+		t.Errorf("Duration() = %v, want %v", d.Duration(), want)
+	}
+}
+
+func TestSecDurationUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var d SecDuration
+	if err := json.Unmarshal([]byte(`3600`), &d); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if want := time.Hour; d.Duration() != want {
+		t.Errorf("Duration() = %v, want %v", d.Duration(), want)
+	}
+}
+
+func TestMsecDurationUnmarshalEmbeddedInStruct(t *testing.T) {
+	t.Parallel()
+
+	var peer BGPPeerSummary
+	if err := json.Unmarshal([]byte(`{"peerUptimeMsec": 1500}`), &peer); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if want := 1500 * time.Millisecond; peer.PeerUptime.Duration() != want {
+		t.Errorf("PeerUptime.Duration() = %v, want %v", peer.PeerUptime.Duration(), want)
+	}
+}
+
+func TestSecDurationUnmarshalEmbeddedInStruct(t *testing.T) {
+	t.Parallel()
+
+	var peer BFDPeer
+	if err := json.Unmarshal([]byte(`{"uptime": 42}`), &peer); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if want := 42 * time.Second; peer.Uptime.Duration() != want {
+		t.Errorf("Uptime.Duration() = %v, want %v", peer.Uptime.Duration(), want)
+	}
+}
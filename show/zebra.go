@@ -0,0 +1,51 @@
+package show
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/RealFatCat/gofrr"
+)
+
+// ZebraNexthop is one nexthop entry of a ZebraRoute.
+type ZebraNexthop struct {
+	Flags             int    `json:"flags"`
+	FIB               bool   `json:"fib"`
+	DirectlyConnected bool   `json:"directlyConnected"`
+	InterfaceName     string `json:"interfaceName"`
+	Active            bool   `json:"active"`
+}
+
+// ZebraRoute is one route entry within ZebraRoutesResponse.
+type ZebraRoute struct {
+	Prefix    string         `json:"prefix"`
+	Protocol  string         `json:"protocol"`
+	VRFID     int            `json:"vrfId"`
+	VRFName   string         `json:"vrfName"`
+	Selected  bool           `json:"selected"`
+	Distance  int            `json:"distance"`
+	Metric    int            `json:"metric"`
+	Installed bool           `json:"installed"`
+	Table     int            `json:"table"`
+	Uptime    string         `json:"uptime"`
+	Nexthops  []ZebraNexthop `json:"nexthops"`
+}
+
+// ZebraRoutesResponse mirrors the JSON produced by 'show ip route json', keyed by route prefix.
+// Equal-cost routes to the same prefix show up as multiple entries in the slice.
+type ZebraRoutesResponse map[string][]ZebraRoute
+
+// ZebraRoutes runs 'show ip route json' on the Zebra socket and unmarshals the result.
+func ZebraRoutes(ctx context.Context, s *gofrr.Sockets) (*ZebraRoutesResponse, error) {
+	resp, err := s.ExecuteZebra(ctx, "show ip route json")
+	if err != nil {
+		return nil, fmt.Errorf("show ip route json: %w", err)
+	}
+
+	var out ZebraRoutesResponse
+	if err := json.Unmarshal(resp, &out); err != nil {
+		return nil, fmt.Errorf("unmarshal show ip route json response: %w", err)
+	}
+	return &out, nil
+}
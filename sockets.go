@@ -1,10 +1,10 @@
 package gofrr
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -36,15 +36,22 @@ type Sockets struct {
 	allConnections []*Connection
 
 	frrConfigPath string
+
+	logger Logger
 }
 
 // NewSockets creates a container with connections to multiple FRR sockets.
 // Currently there is support of connecting to BFD, BGP, Mgmt and Zebra sockets.
-func NewSockets(frrConfigPath string, frrRunDir string) *Sockets {
-	bgpConn := NewConnection(filepath.Join(frrRunDir, bgpSocketName))
-	bfdConn := NewConnection(filepath.Join(frrRunDir, bfdSocketName))
-	mgmtConn := NewConnection(filepath.Join(frrRunDir, mgmtSocketName))
-	zebraConn := NewConnection(filepath.Join(frrRunDir, zebraSocketName))
+func NewSockets(frrConfigPath string, frrRunDir string, opts ...Option) *Sockets {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	bgpConn := NewConnection(filepath.Join(frrRunDir, bgpSocketName), opts...)
+	bfdConn := NewConnection(filepath.Join(frrRunDir, bfdSocketName), opts...)
+	mgmtConn := NewConnection(filepath.Join(frrRunDir, mgmtSocketName), opts...)
+	zebraConn := NewConnection(filepath.Join(frrRunDir, zebraSocketName), opts...)
 
 	// this is a helper to iterate over sockets for Connect(), Close() and DumpRunningConfig() methods.
 	allConnections := []*Connection{
@@ -63,6 +70,8 @@ func NewSockets(frrConfigPath string, frrRunDir string) *Sockets {
 		allConnections: allConnections,
 
 		frrConfigPath: frrConfigPath,
+
+		logger: o.logger,
 	}
 }
 
@@ -70,9 +79,17 @@ func (s *Sockets) execute(ctx context.Context, conn *Connection, cmd string) ([]
 	return conn.Execute(ctx, cmd)
 }
 
+func (s *Sockets) executeStream(ctx context.Context, conn *Connection, cmd string) (<-chan []byte, <-chan error) {
+	return conn.ExecuteStream(ctx, cmd)
+}
+
+func (s *Sockets) executeIdempotent(ctx context.Context, conn *Connection, cmd string) ([]byte, error) {
+	return conn.ExecuteIdempotent(ctx, cmd)
+}
+
 // ExecuteBFD executes a command on the BFD socket.
 func (s *Sockets) ExecuteBFD(ctx context.Context, cmd string) ([]byte, error) {
-	return s.execute(ctx, s.mgmtConn, cmd)
+	return s.execute(ctx, s.bfdConn, cmd)
 }
 
 // ExecuteBGP executes a command on the BGP socket.
@@ -90,15 +107,61 @@ func (s *Sockets) ExecuteZebra(ctx context.Context, cmd string) ([]byte, error)
 	return s.execute(ctx, s.zebraConn, cmd)
 }
 
+// ExecuteStreamBFD executes a command on the BFD socket, streaming the response in chunks.
+func (s *Sockets) ExecuteStreamBFD(ctx context.Context, cmd string) (<-chan []byte, <-chan error) {
+	return s.executeStream(ctx, s.bfdConn, cmd)
+}
+
+// ExecuteStreamBGP executes a command on the BGP socket, streaming the response in chunks.
+func (s *Sockets) ExecuteStreamBGP(ctx context.Context, cmd string) (<-chan []byte, <-chan error) {
+	return s.executeStream(ctx, s.bgpConn, cmd)
+}
+
+// ExecuteStreamMgmt executes a command on the Mgmt socket, streaming the response in chunks.
+func (s *Sockets) ExecuteStreamMgmt(ctx context.Context, cmd string) (<-chan []byte, <-chan error) {
+	return s.executeStream(ctx, s.mgmtConn, cmd)
+}
+
+// ExecuteStreamZebra executes a command on the Zebra socket, streaming the response in chunks.
+func (s *Sockets) ExecuteStreamZebra(ctx context.Context, cmd string) (<-chan []byte, <-chan error) {
+	return s.executeStream(ctx, s.zebraConn, cmd)
+}
+
+// ExecuteIdempotentBFD executes an idempotent command on the BFD socket, transparently retrying
+// on transient connection failures. See Connection.ExecuteIdempotent.
+func (s *Sockets) ExecuteIdempotentBFD(ctx context.Context, cmd string) ([]byte, error) {
+	return s.executeIdempotent(ctx, s.bfdConn, cmd)
+}
+
+// ExecuteIdempotentBGP executes an idempotent command on the BGP socket, transparently retrying
+// on transient connection failures. See Connection.ExecuteIdempotent.
+func (s *Sockets) ExecuteIdempotentBGP(ctx context.Context, cmd string) ([]byte, error) {
+	return s.executeIdempotent(ctx, s.bgpConn, cmd)
+}
+
+// ExecuteIdempotentMgmt executes an idempotent command on the Mgmt socket, transparently retrying
+// on transient connection failures. See Connection.ExecuteIdempotent.
+func (s *Sockets) ExecuteIdempotentMgmt(ctx context.Context, cmd string) ([]byte, error) {
+	return s.executeIdempotent(ctx, s.mgmtConn, cmd)
+}
+
+// ExecuteIdempotentZebra executes an idempotent command on the Zebra socket, transparently
+// retrying on transient connection failures. See Connection.ExecuteIdempotent.
+func (s *Sockets) ExecuteIdempotentZebra(ctx context.Context, cmd string) ([]byte, error) {
+	return s.executeIdempotent(ctx, s.zebraConn, cmd)
+}
+
 // Connect establishes connections with all FRR sockets.
 func (s *Sockets) Connect(ctx context.Context) (err error) {
 	for _, c := range s.allConnections {
 		err = errors.Join(err, c.Connect(ctx))
 	}
 	if err != nil {
+		s.logger.Errorf("connect sockets: err=%v", err)
 		err = errors.Join(err, s.Close())
 		return err
 	}
+	s.logger.Infof("connect sockets: connected to %d sockets", len(s.allConnections))
 	return nil
 }
 
@@ -120,6 +183,16 @@ func (s *Sockets) ApplyMgmtConfig(ctx context.Context, config []byte) error {
 	return s.mgmtConn.ApplyConfig(ctx, config)
 }
 
+// ApplyBGPConfigTx passes configuration to BGP daemon transactionally, see Connection.ApplyConfigTx.
+func (s *Sockets) ApplyBGPConfigTx(ctx context.Context, config []byte, opts ApplyConfigTxOptions) (TxResult, error) {
+	return s.bgpConn.ApplyConfigTx(ctx, config, opts)
+}
+
+// ApplyMgmtConfigTx passes configuration to Mgmt daemon transactionally, see Connection.ApplyConfigTx.
+func (s *Sockets) ApplyMgmtConfigTx(ctx context.Context, config []byte, opts ApplyConfigTxOptions) (TxResult, error) {
+	return s.mgmtConn.ApplyConfigTx(ctx, config, opts)
+}
+
 // ShowRunningConfigBGP is a helper to get current configuration of BGP daemon.
 func (s *Sockets) ShowRunningConfigBGP(ctx context.Context) ([]byte, error) {
 	return s.bgpConn.ShowRunningConfig(ctx)
@@ -131,43 +204,46 @@ func (s *Sockets) ShowRunningConfigMgmt(ctx context.Context) ([]byte, error) {
 }
 
 // DumpRunningConfig runs 'do write terminal' (analog of 'show running-config' command) to all connected sockets.
-// After getting all seprate configurations, it merges them in one, and atomicly writes the result to `dstFile` with specified `mode` permissions.
+// Each daemon's config is streamed directly into a temporary file as it arrives, rather than being
+// concatenated in memory first, which matters for daemons with very large configs (e.g. BGP with tens
+// of thousands of prefixes). The temporary file is then atomically renamed to `dstFile` with specified
+// `mode` permissions.
 func (s *Sockets) DumpRunningConfig(ctx context.Context, dstFile string, mode fs.FileMode) (err error) {
-	var resultConfig bytes.Buffer
-	for _, conn := range s.allConnections {
-		var resp []byte
-		resp, err = conn.ShowRunningConfig(ctx)
-		if err != nil {
-			return fmt.Errorf("show running config, resp: %s, err: %w", resp, err)
-		}
+	tmpPath := dstFile + ".tmp"
 
-		// Add information about socket to config comment.
-		comment := fmt.Appendf([]byte{}, "! %s\n", conn.socketPath)
-		if _, err = resultConfig.Write(comment); err != nil {
-			return fmt.Errorf("write comment to buffer: %w", err)
-		}
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return fmt.Errorf("create temporary file %q: %w", tmpPath, err)
+	}
 
-		if _, err = resultConfig.Write(resp); err != nil {
-			return fmt.Errorf("write response to buffer: %w", err)
-		}
+	if err = s.writeRunningConfig(ctx, f); err != nil {
+		s.logger.Errorf("dump running config: dst=%s err=%v", dstFile, err)
+		_ = f.Close()
+		return err
 	}
 
-	if err := atomicWrite(dstFile, resultConfig.Bytes(), mode); err != nil {
-		return fmt.Errorf("atomic write for %s: %w", s.frrConfigPath, err)
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("close temporary file %q: %w", tmpPath, err)
 	}
 
+	if err = os.Rename(tmpPath, dstFile); err != nil {
+		return fmt.Errorf("renaming temporary file %q to destination file %q: %w", tmpPath, dstFile, err)
+	}
+
+	s.logger.Infof("dump running config: dst=%s", dstFile)
 	return nil
 }
 
-func atomicWrite(path string, data []byte, perm os.FileMode) error {
-	tmpPath := path + ".tmp"
-
-	if err := os.WriteFile(tmpPath, data, perm); err != nil {
-		return fmt.Errorf("writing temporary file %q: %w", tmpPath, err)
-	}
+func (s *Sockets) writeRunningConfig(ctx context.Context, w io.Writer) error {
+	for _, conn := range s.allConnections {
+		// Add information about socket to config comment.
+		if _, err := fmt.Fprintf(w, "! %s\n", conn.socketPath); err != nil {
+			return fmt.Errorf("write comment for %s: %w", conn.socketPath, err)
+		}
 
-	if err := os.Rename(tmpPath, path); err != nil {
-		return fmt.Errorf("renaming temporary file %q to destination file %q: %w", tmpPath, path, err)
+		if err := conn.streamRunningConfig(ctx, w); err != nil {
+			return fmt.Errorf("show running config for %s: %w", conn.socketPath, err)
+		}
 	}
 	return nil
 }
@@ -5,7 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
+	"syscall"
 	"time"
 )
 
@@ -39,6 +41,9 @@ type Connection struct {
 	socketPath string
 
 	conn net.Conn
+
+	logger  Logger
+	backoff Backoff
 }
 
 var (
@@ -48,14 +53,39 @@ var (
 
 // NecConnect creates new connection to FRR socket.
 // It does not establish connection, it just creates new instance of Connection.
-func NewConnection(socketPath string) *Connection {
+func NewConnection(socketPath string, opts ...Option) *Connection {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	return &Connection{
 		socketPath: socketPath,
+		logger:     o.logger,
+		backoff:    o.backoff,
+	}
+}
+
+// Connect establishes connection to socket, retrying with the Connection's backoff policy on
+// failure until the context is done or Backoff.MaxAttempts is reached.
+func (c *Connection) Connect(ctx context.Context) error {
+	var lastErr error
+	for attempt := 0; c.backoff.MaxAttempts == 0 || attempt < c.backoff.MaxAttempts; attempt++ {
+		if err := c.dialOnce(ctx); err != nil {
+			lastErr = err
+			c.logger.Warnf("connect: socket=%s attempt=%d err=%v", c.socketPath, attempt, err)
+		} else {
+			return nil
+		}
+
+		if !c.wait(ctx, attempt) {
+			return errors.Join(lastErr, ctx.Err())
+		}
 	}
+	return fmt.Errorf("connect %s: max attempts (%d) reached: %w", c.socketPath, c.backoff.MaxAttempts, lastErr)
 }
 
-// Connect establishes connection to socket.
-func (c *Connection) Connect(ctx context.Context) (err error) {
+func (c *Connection) dialOnce(ctx context.Context) error {
 	var d net.Dialer
 
 	conn, err := d.DialContext(ctx, "unix", c.socketPath)
@@ -66,6 +96,20 @@ func (c *Connection) Connect(ctx context.Context) (err error) {
 	return nil
 }
 
+// wait blocks for the backoff delay of the given attempt, or until ctx is done, whichever comes
+// first. It reports whether the wait completed normally (false means ctx is done).
+func (c *Connection) wait(ctx context.Context, attempt int) bool {
+	timer := time.NewTimer(c.backoff.Delay(attempt))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
 // Close closes connection to socket.
 func (c *Connection) Close() error {
 	if c.conn == nil {
@@ -83,31 +127,204 @@ func (c *Connection) Close() error {
 // Execute runs one command via socket.
 // Deadlines for both read and write can be set via context.WithDeadline.
 // Default timeout is 1 second.
-func (c *Connection) Execute(ctx context.Context, cmd string) (resp []byte, err error) {
+func (c *Connection) Execute(ctx context.Context, cmd string) ([]byte, error) {
+	resp, _, err := c.executeStatus(ctx, cmd)
+	return resp, err
+}
+
+// executeStatus is the shared implementation behind Execute, also returning the raw StatusCode
+// so that callers needing per-command status (e.g. ApplyConfigTx) don't have to re-parse errors.
+// It logs socket path and command for every command it runs, plus status code, bytes read and
+// duration once a complete response has been read.
+func (c *Connection) executeStatus(ctx context.Context, cmd string) (resp []byte, sc StatusCode, err error) {
+	start := time.Now()
+
 	if c.conn == nil {
-		return nil, fmt.Errorf("%w %s", ErrConnNotEstab, c.socketPath)
+		err = fmt.Errorf("%w %s", ErrConnNotEstab, c.socketPath)
+		c.logger.Errorf("exec command: socket=%s cmd=%q err=%v", c.socketPath, cmd, err)
+		return nil, 0, err
 	}
 
 	if err = c.setDeadline(ctx); err != nil {
-		return nil, fmt.Errorf("set connection deadline %s: %w", c.socketPath, err)
+		err = fmt.Errorf("set connection deadline %s: %w", c.socketPath, err)
+		c.logger.Errorf("exec command: socket=%s cmd=%q err=%v", c.socketPath, cmd, err)
+		return nil, 0, err
 	}
 
 	if _, err = c.writeCommand(cmd); err != nil {
-		return nil, fmt.Errorf("write %q to socket %q: %w", cmd, c.socketPath, err)
+		err = fmt.Errorf("write %q to socket %q: %w", cmd, c.socketPath, err)
+		c.logger.Errorf("exec command: socket=%s cmd=%q err=%v", c.socketPath, cmd, err)
+		return nil, 0, err
 	}
 
 	response := c.readResponse()
+	duration := time.Since(start)
 	if response.err != nil {
-		return response.plainText, response.err
+		c.logger.Errorf("exec command: socket=%s cmd=%q bytes=%d duration=%s err=%v", c.socketPath, cmd, len(response.plainText), duration, response.err)
+		return response.plainText, response.statusCode, response.err
 	}
 
-	sc := response.statusCode
+	sc = response.statusCode
 
-	// Got this check from frr vtysh_main.c, look for vtysh_execute_no_pager.
-	if sc != Success && sc != Warning && sc != SuccessDaemon {
-		return response.plainText, fmt.Errorf("%w, FRR command %q on socket %q: %s", ErrNotAcceptableStatusCode, cmd, c.socketPath, sc.String())
+	if !sc.isAcceptable() {
+		err = fmt.Errorf("%w, FRR command %q on socket %q: %s", ErrNotAcceptableStatusCode, cmd, c.socketPath, sc.String())
+		c.logger.Warnf("exec command rejected: socket=%s cmd=%q status=%s bytes=%d duration=%s", c.socketPath, cmd, sc.String(), len(response.plainText), duration)
+		return response.plainText, sc, err
+	}
+
+	c.logger.Debugf("exec command: socket=%s cmd=%q status=%s bytes=%d duration=%s", c.socketPath, cmd, sc.String(), len(response.plainText), duration)
+	return response.plainText, sc, nil
+}
+
+// ExecuteIdempotent is like Execute, but on a connection-level failure (as opposed to an
+// FRR-level ErrNotAcceptableStatusCode) it transparently re-dials the socket and replays cmd,
+// retrying with the Connection's backoff policy until the context is done or Backoff.MaxAttempts
+// is reached. Only use this for commands that are safe to run more than once, typically
+// 'show ...' queries: replaying part of a 'configure' batch could corrupt daemon state.
+func (c *Connection) ExecuteIdempotent(ctx context.Context, cmd string) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; c.backoff.MaxAttempts == 0 || attempt < c.backoff.MaxAttempts; attempt++ {
+		// If a previous attempt closed the connection (below) or it was never dialed, redial
+		// before trying to execute, retrying the dial itself with backoff on failure rather than
+		// giving up as soon as one redial attempt fails — a daemon that's mid-restart can easily
+		// take more than one attempt to come back.
+		if c.conn == nil {
+			if err := c.dialOnce(ctx); err != nil {
+				lastErr = err
+				c.logger.Warnf("exec idempotent: socket=%s cmd=%q attempt=%d reconnect failed: %v", c.socketPath, cmd, attempt, err)
+				if !c.wait(ctx, attempt) {
+					return nil, errors.Join(lastErr, ctx.Err())
+				}
+				continue
+			}
+		}
+
+		resp, err := c.Execute(ctx, cmd)
+		if err == nil || !isTransientConnError(err) {
+			return resp, err
+		}
+
+		lastErr = err
+		c.logger.Warnf("exec idempotent: socket=%s cmd=%q attempt=%d err=%v, reconnecting", c.socketPath, cmd, attempt, err)
+
+		_ = c.Close()
+		if !c.wait(ctx, attempt) {
+			return nil, errors.Join(lastErr, ctx.Err())
+		}
+	}
+	return nil, fmt.Errorf("exec idempotent %q on socket %q: max attempts (%d) reached: %w", cmd, c.socketPath, c.backoff.MaxAttempts, lastErr)
+}
+
+// isTransientConnError reports whether err is a connection-level failure that can be recovered
+// from by re-dialing the socket, as opposed to an FRR-level rejection of the command itself.
+func isTransientConnError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, net.ErrClosed) || errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return isTransientConnError(opErr.Err)
+	}
+	return false
+}
+
+// ExecuteStream runs one command via socket, delivering the plaintext response in chunks
+// as they arrive on the chunk channel, instead of buffering the whole response in memory.
+// Once the response is fully read, the status code is checked: a non-Success/Warning/SuccessDaemon
+// code is sent on the error channel as ErrNotAcceptableStatusCode, just like Execute, and so is
+// any connection error. Both channels are then closed, so on success the error channel yields nil
+// without a value having been sent.
+// Deadlines for both read and write can be set via context.WithDeadline. Default timeout is 1 second.
+func (c *Connection) ExecuteStream(ctx context.Context, cmd string) (<-chan []byte, <-chan error) {
+	chunkCh := make(chan []byte)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(chunkCh)
+		defer close(errCh)
+
+		if c.conn == nil {
+			errCh <- fmt.Errorf("%w %s", ErrConnNotEstab, c.socketPath)
+			return
+		}
+
+		if err := c.setDeadline(ctx); err != nil {
+			errCh <- fmt.Errorf("set connection deadline %s: %w", c.socketPath, err)
+			return
+		}
+
+		if _, err := c.writeCommand(cmd); err != nil {
+			errCh <- fmt.Errorf("write %q to socket %q: %w", cmd, c.socketPath, err)
+			return
+		}
+
+		sc, err := c.streamResponse(chunkCh)
+		if err != nil {
+			errCh <- err
+			return
+		}
+
+		if !sc.isAcceptable() {
+			errCh <- fmt.Errorf("%w, FRR command %q on socket %q: %s", ErrNotAcceptableStatusCode, cmd, c.socketPath, sc.String())
+		}
+	}()
+
+	return chunkCh, errCh
+}
+
+// streamResponse reads the socket response and emits plaintext chunks on chunkCh as they arrive.
+// Because the 3-byte termination marker (and the status byte that follows it) can be split
+// across two reads, the last len(terminationMarker)-1 bytes of unemitted data are always
+// withheld and prepended to the next read, so a marker is never split across two emitted chunks.
+func (c *Connection) streamResponse(chunkCh chan<- []byte) (StatusCode, error) {
+	if c.conn == nil {
+		return 0, fmt.Errorf("%w %s", ErrConnNotEstab, c.socketPath)
+	}
+
+	bufSize := 4096
+	buf := make([]byte, bufSize)
+
+	var pending []byte
+
+	for {
+		n, err := c.conn.Read(buf)
+		if err != nil {
+			return 0, err
+		}
+
+		pending = append(pending, buf[:n]...)
+
+		idx := bytes.Index(pending, terminationMarker)
+		if idx < 0 {
+			// No marker yet: emit everything except the last few bytes, which might be
+			// the start of a marker split across this read and the next one.
+			keep := len(terminationMarker) - 1
+			if len(pending) <= keep {
+				continue
+			}
+
+			if emit := pending[:len(pending)-keep]; len(emit) > 0 {
+				chunkCh <- append([]byte(nil), emit...)
+			}
+			pending = pending[len(pending)-keep:]
+			continue
+		}
+
+		// Marker found, but the status byte right after it may not have arrived yet.
+		if len(pending)-idx < techDataTotalLen {
+			continue
+		}
+
+		if emit := pending[:idx]; len(emit) > 0 {
+			chunkCh <- append([]byte(nil), emit...)
+		}
+		return StatusCode(pending[idx+len(terminationMarker)]), nil
 	}
-	return response.plainText, nil
 }
 
 func (c *Connection) setDeadline(ctx context.Context) error {
@@ -197,6 +414,183 @@ func (c *Connection) ApplyConfig(ctx context.Context, config []byte) error {
 	return nil
 }
 
+// PreCheckFunc validates a single command line before it is applied, typically by running it
+// through FRR's completion mechanism. It should return one of the completion status codes from
+// lib/command.h, e.g. CompleteFullMatch, ErrAmbiguous or ErrIncomplete.
+type PreCheckFunc func(ctx context.Context, cmd string) (StatusCode, error)
+
+// ApplyConfigTxOptions controls the behavior of ApplyConfigTx.
+type ApplyConfigTxOptions struct {
+	// RollbackOnError restores the daemon's previous running-config, captured right before the
+	// transaction starts, if any command in the batch fails.
+	RollbackOnError bool
+
+	// StopOnFirstError stops applying the remaining commands in the batch as soon as one fails.
+	// When false, ApplyConfigTx keeps applying the rest of the batch and reports every failure
+	// in the returned TxResult.
+	StopOnFirstError bool
+
+	// PreCheck, when set, validates every command line via FRR's completion mechanism before any
+	// of them is applied. A line that doesn't resolve to CompleteFullMatch aborts the whole
+	// transaction before any mutation happens.
+	PreCheck PreCheckFunc
+}
+
+// TxLineResult reports the execution result of one command line within a transaction.
+type TxLineResult struct {
+	Command    string
+	StatusCode StatusCode
+	Err        error
+}
+
+// TxResult is returned by ApplyConfigTx and reports the outcome of the whole transaction.
+type TxResult struct {
+	// Lines holds one entry per command line that was actually executed, in order.
+	Lines []TxLineResult
+
+	// RolledBack is true if the transaction failed and its previous running-config was replayed.
+	// This is a best-effort revert, not a guarantee of a clean one: see the RollbackOnError
+	// caveat on ApplyConfigTx.
+	RolledBack bool
+}
+
+// ApplyConfigTx is a transactional variant of ApplyConfig. When opts.RollbackOnError is set, it
+// snapshots the current running-config via ShowRunningConfig before entering 'configure', and if
+// any command in config fails, exits 'config'/'enable', replays that snapshot via ApplyConfig
+// (which enters and exits those modes itself) and returns the original error. The returned TxResult reports the status
+// code of every line that was executed, so callers can see exactly which command rejected.
+//
+// The rollback is a best-effort replay, not a true revert: FRR's CLI is additive, so replaying
+// the old snapshot re-asserts every line it contains but does not emit 'no ...' for anything the
+// failed batch added before the command that rejected (e.g. a new 'neighbor' line under
+// 'router bgp' from an earlier, successful command in the same batch). Callers that need a clean
+// revert in that case must diff the snapshot against the post-failure running-config themselves
+// and issue the 'no ...' commands for what ApplyConfigTx left behind.
+func (c *Connection) ApplyConfigTx(ctx context.Context, config []byte, opts ApplyConfigTxOptions) (TxResult, error) {
+	var result TxResult
+
+	commands := bytes.Split(config, []byte("\n"))
+
+	if opts.PreCheck != nil {
+		for _, cmd := range commands {
+			cmd = bytes.TrimSpace(cmd)
+			if len(cmd) == 0 {
+				continue
+			}
+
+			sc, err := opts.PreCheck(ctx, string(cmd))
+			if err != nil {
+				return result, fmt.Errorf("pre-check command '%s': %w", cmd, err)
+			}
+			if sc != CompleteFullMatch {
+				return result, fmt.Errorf("pre-check command '%s': %w, status %s", cmd, ErrNotAcceptableStatusCode, sc.String())
+			}
+		}
+	}
+
+	var snapshot []byte
+	if opts.RollbackOnError {
+		var err error
+		snapshot, err = c.ShowRunningConfig(ctx)
+		if err != nil {
+			return result, fmt.Errorf("snapshot running config before tx: %w", err)
+		}
+	}
+
+	// Preparations: enter modes
+	for _, cmd := range []string{"enable", "configure"} {
+		if _, err := c.Execute(ctx, cmd); err != nil {
+			return result, fmt.Errorf("could not run %s command: %w", cmd, err)
+		}
+	}
+
+	// Run config commands
+	var firstErr error
+	for _, cmd := range commands {
+		cmd = bytes.TrimSpace(cmd)
+		if len(cmd) == 0 {
+			continue
+		}
+
+		resp, sc, err := c.executeStatus(ctx, string(cmd))
+		result.Lines = append(result.Lines, TxLineResult{Command: string(cmd), StatusCode: sc, Err: err})
+
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("exec command '%s' on frr socket %s while applying config: %w, resp: %s", cmd, c.socketPath, err, resp)
+			}
+			if opts.StopOnFirstError {
+				break
+			}
+		}
+	}
+
+	// Cleanups: exit modes. A failure here must not short-circuit past the rollback logic below:
+	// it's joined into the returned error instead, since firstErr (and a pending rollback) is
+	// exactly what this function exists to surface.
+	var cleanupErr error
+	for _, cmd := range []string{"exit", "disable"} {
+		if _, err := c.Execute(ctx, cmd); err != nil {
+			cleanupErr = errors.Join(cleanupErr, fmt.Errorf("could not run %s command: %w", cmd, err))
+		}
+	}
+
+	if firstErr == nil {
+		return result, cleanupErr
+	}
+
+	if opts.RollbackOnError {
+		if rbErr := c.ApplyConfig(ctx, stripRunningConfigBanner(snapshot)); rbErr != nil {
+			return result, errors.Join(firstErr, cleanupErr, fmt.Errorf("rollback after tx failure: %w", rbErr))
+		}
+		result.RolledBack = true
+	}
+
+	return result, errors.Join(firstErr, cleanupErr)
+}
+
+// runningConfigBannerPrefix and runningConfigHeaderPrefix are the non-command lines 'do write
+// terminal' prints before the actual configuration; runningConfigTrailer is the line it prints
+// after. None of these are valid commands in 'configure' mode.
+var (
+	runningConfigBannerPrefix = []byte("Building configuration...")
+	runningConfigHeaderPrefix = []byte("Current configuration:")
+	runningConfigTrailer      = []byte("end")
+)
+
+// stripRunningConfigBanner removes the leading 'Building configuration...'/'Current
+// configuration:' banner and the trailing 'end' line that ShowRunningConfig's 'do write terminal'
+// output carries, so the remaining lines can be replayed through ApplyConfig, which treats every
+// non-blank line as a command to execute.
+func stripRunningConfigBanner(config []byte) []byte {
+	lines := bytes.Split(config, []byte("\n"))
+
+	start := 0
+	for start < len(lines) {
+		line := bytes.TrimSpace(lines[start])
+		if len(line) == 0 || bytes.HasPrefix(line, runningConfigBannerPrefix) || bytes.HasPrefix(line, runningConfigHeaderPrefix) {
+			start++
+			continue
+		}
+		break
+	}
+
+	end := len(lines)
+	for end > start {
+		line := bytes.TrimSpace(lines[end-1])
+		if len(line) == 0 {
+			end--
+			continue
+		}
+		if bytes.Equal(line, runningConfigTrailer) {
+			end--
+		}
+		break
+	}
+
+	return bytes.Join(lines[start:end], []byte("\n"))
+}
+
 // ShowRunningConfig is a helper to get current config of the daemon we are connected to.
 // It is better to close connection if any error returns, due to unknown mode of the current connection.
 func (c *Connection) ShowRunningConfig(ctx context.Context) ([]byte, error) {
@@ -216,3 +610,29 @@ func (c *Connection) ShowRunningConfig(ctx context.Context) ([]byte, error) {
 	}
 	return response, nil
 }
+
+// streamRunningConfig is like ShowRunningConfig, but streams the response directly into w
+// instead of buffering it in memory. Used by Sockets.DumpRunningConfig for large configs.
+// It is better to close connection if any error returns, due to unknown mode of the current connection.
+func (c *Connection) streamRunningConfig(ctx context.Context, w io.Writer) error {
+	if r, err := c.Execute(ctx, "enable"); err != nil {
+		return fmt.Errorf("show running config `enable` command, resp: %s, err: %w", r, err)
+	}
+
+	// analog of 'show running-config'
+	chunkCh, errCh := c.ExecuteStream(ctx, "do write terminal")
+	for chunk := range chunkCh {
+		if _, err := w.Write(chunk); err != nil {
+			return fmt.Errorf("show running config `do write terminal` command: write chunk: %w", err)
+		}
+	}
+	if err := <-errCh; err != nil {
+		return fmt.Errorf("show running config `do write terminal` command: %w", err)
+	}
+
+	// Cleanup
+	if r, err := c.Execute(ctx, "disable"); err != nil {
+		return fmt.Errorf("show running config `disable` command, resp: %s, err: %w", r, err)
+	}
+	return nil
+}
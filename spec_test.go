@@ -0,0 +1,83 @@
+package gofrr
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+)
+
+func writeInChunks(w io.Writer, data []byte, size int) error {
+	for len(data) > 0 {
+		n := size
+		if n > len(data) {
+			n = len(data)
+		}
+		if _, err := w.Write(data[:n]); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+// TestConnectionStreamResponseMarkerSplitAcrossReads exercises streamResponse's boundary scan by
+// writing the response in chunk sizes that deliberately split the termination marker (and the
+// status byte after it) across multiple reads, and checks that the marker never leaks into an
+// emitted chunk and the reassembled plaintext and status code are still correct.
+func TestConnectionStreamResponseMarkerSplitAcrossReads(t *testing.T) {
+	t.Parallel()
+
+	plainText := []byte("hello world, this is a fairly long response payload for testing")
+	full := append(append([]byte{}, plainText...), terminationMarker...)
+	full = append(full, byte(Success))
+
+	for _, chunkSize := range []int{1, 2, 3, 4, 5, 7, 64} {
+		chunkSize := chunkSize
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+
+			serverConn, clientConn := net.Pipe()
+			c := &Connection{socketPath: "test", conn: clientConn, logger: noopLogger{}}
+
+			writeErrCh := make(chan error, 1)
+			go func() {
+				defer serverConn.Close()
+				writeErrCh <- writeInChunks(serverConn, full, chunkSize)
+			}()
+
+			chunkCh := make(chan []byte)
+			var got []byte
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for chunk := range chunkCh {
+					// The marker must never be split across chunks, which in particular means it
+					// must never appear inside an emitted chunk at all.
+					if bytes.Contains(chunk, terminationMarker) {
+						t.Errorf("chunk size %d: emitted chunk contains termination marker: %q", chunkSize, chunk)
+					}
+					got = append(got, chunk...)
+				}
+			}()
+
+			sc, err := c.streamResponse(chunkCh)
+			close(chunkCh)
+			<-done
+			_ = clientConn.Close()
+
+			if err != nil {
+				t.Fatalf("chunk size %d: streamResponse returned error: %v", chunkSize, err)
+			}
+			if err := <-writeErrCh; err != nil {
+				t.Fatalf("chunk size %d: write side failed: %v", chunkSize, err)
+			}
+			if sc != Success {
+				t.Fatalf("chunk size %d: status code = %v, want %v", chunkSize, sc, Success)
+			}
+			if !bytes.Equal(got, plainText) {
+				t.Fatalf("chunk size %d: emitted plaintext = %q, want %q", chunkSize, got, plainText)
+			}
+		})
+	}
+}
@@ -65,3 +65,10 @@ func (sc StatusCode) String() string {
 		return "unknown status code"
 	}
 }
+
+// isAcceptable reports whether sc is a status code that FRR returns for a command that actually
+// ran, as opposed to one that was rejected. Got this check from frr vtysh_main.c, look for
+// vtysh_execute_no_pager.
+func (sc StatusCode) isAcceptable() bool {
+	return sc == Success || sc == Warning || sc == SuccessDaemon
+}